@@ -3,13 +3,30 @@ package config
 
 import (
 	"fmt"
-	"os"
 	"reflect"
 	"strconv"
 	"strings"
 	"unicode"
 )
 
+// Struct tags recognised on fields while walking an input to [LoadFromEnvironment].
+const (
+	// tagEnv overrides the derived environment variable name for a field. A value of "-"
+	// excludes the field entirely.
+	tagEnv = "env"
+	// tagEnvDefault seeds a value to use when the environment variable is unset.
+	tagEnvDefault = "envDefault"
+	// tagEnvRequired fails loading when set to "true" and the environment variable (and any
+	// envDefault) is unset.
+	tagEnvRequired = "envRequired"
+	// tagEnvSeparator overrides the default "," separator between slice elements or map entries
+	// used when parsing a scalar slice or map from a single environment value. A map entry's key
+	// and value are always split on the first ":"; this tag doesn't change that.
+	tagEnvSeparator = "envSeparator"
+	// tagEnvLayout overrides the default RFC3339 layout used to parse a time.Time field.
+	tagEnvLayout = "envLayout"
+)
+
 // ParseFromEnvironment walks a input looking for corresponding environment variables, which if found
 // will update the structures value. Only exported fields of structured inputs are considered.
 
@@ -20,74 +37,211 @@ import (
 //
 // Basic data types can also be used by populating the prefix argument to match the exact corresponding
 // environment variable key name.
+//
+// Struct fields may carry an `env:"NAME"` tag to override the derived key, an `env:"-"` tag to
+// skip the field, an `envDefault:"..."` tag to seed a value when the variable is unset, and an
+// `envRequired:"true"` tag to fail loading when the variable (and any default) is missing. Missing
+// required fields are collected and returned together as a [RequiredFieldsError] once the whole
+// input has been walked.
+//
+// Beyond strings, bools, and integers, fields of type time.Duration, time.Time (RFC3339 by
+// default, overridable via `envLayout:"..."`), *url.URL, unsigned integers, floats, []byte
+// (base64), and any type implementing [encoding.TextUnmarshaler] or [encoding.BinaryUnmarshaler]
+// are parsed directly from their environment value. Slices and maps of these scalar types are
+// populated from a single separated value (`envSeparator:","` by default, `:` between a map
+// entry's key and value). Slices and arrays of structs are instead walked element by element,
+// every element reading the same keys; maps whose values aren't scalars are skipped by this
+// field-by-field walk.
+//
+// A field's resolved value can itself be indirected through a [ValueResolver]: an explicit
+// `envSource:"..."` (or `envFrom:"..."`) tag, or a raw value shaped like "scheme://...", routes
+// it through the resolver registered under that scheme (see [WithValueResolver]); the built-in
+// "file" scheme reads the value as a path and uses the referenced file's contents, the usual
+// docker-secrets/Kubernetes-secret-volume pattern. A `secret:"true"` tag doesn't affect loading,
+// but marks the field so [Redact] omits its value from its output.
 func LoadFromEnvironment[T any, PtrT *T](pt PtrT, prefix string) error {
-	ptr := reflect.ValueOf(pt)
-	err := loadRecursive(reflect.Indirect(ptr), prefix)
-	if err != nil {
-		return err
-	}
-	return nil
+	return Load(pt, WithPrefix(prefix))
 }
 
 // MustLoadFromEnvironment behaves the same as [LoadFromEnvironment] but will panic instead of
 // returning any errors.
 func MustLoadFromEnvironment[T any, PtrT *T](pt PtrT, prefix string) {
-	ptr := reflect.ValueOf(pt)
-	err := loadRecursive(reflect.Indirect(ptr), prefix)
-	if err != nil {
+	if err := LoadFromEnvironment(pt, prefix); err != nil {
 		panic(err)
 	}
 }
 
-func loadRecursive(val reflect.Value, environmentKey string) error {
-	if val.Kind() == reflect.Struct {
+// Load behaves like [LoadFromEnvironment] but resolves values against a configurable, layered
+// set of [Source]s instead of only the process environment. With no options it's exactly
+// equivalent to LoadFromEnvironment(pt, ""); use [WithPrefix] and [WithSources] (or its alias
+// [WithLookupOrder]) to customize either. This lets services deployed with a mounted config file
+// plus environment overrides populate their config through a single call, e.g.:
+//
+//	err := Load(&cfg, WithSources(config.EnvSource{}, fileSource))
+//
+// After the struct has been walked field by field, Load makes a second pass over every key any
+// source can enumerate (see [KeyEnumerator]) and uses the ones under prefix to populate map
+// fields whose keys aren't known ahead of time - including map[string]interface{} trees, which
+// are built up as needed.
+func Load[T any, PtrT *T](pt PtrT, opts ...Option) error {
+	o := loadOptions{
+		sources:   []Source{EnvSource{}},
+		resolvers: map[string]ValueResolver{"file": FileValueResolver{}},
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	ptr := reflect.Indirect(reflect.ValueOf(pt))
+	sources := sourceList(o.sources)
+
+	var missing []error
+	if err := loadRecursive(ptr, o.prefix, "", sources, o.resolvers, &missing); err != nil {
+		return err
+	}
+	if err := applyEnvironmentOverrides(ptr, o.prefix, sources); err != nil {
+		return err
+	}
+	if len(missing) > 0 {
+		return &RequiredFieldsError{Errs: missing}
+	}
+	return nil
+}
+
+// RequiredFieldsError reports one or more environment variables that were marked
+// `envRequired:"true"` but had no value and no `envDefault`.
+type RequiredFieldsError struct {
+	Errs []error
+}
+
+func (e *RequiredFieldsError) Error() string {
+	msgs := make([]string, len(e.Errs))
+	for i, err := range e.Errs {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap allows errors.Is and errors.As to inspect the individual missing-field errors.
+func (e *RequiredFieldsError) Unwrap() []error {
+	return e.Errs
+}
+
+func loadRecursive(val reflect.Value, environmentKey string, tag reflect.StructTag, source Source, resolvers map[string]ValueResolver, missing *[]error) error {
+	t := val.Type()
+
+	// time.Time, net.IP, and friends are structs/slices in their own right but are populated
+	// from a single environment value, so they're handled as leaves rather than recursed into.
+	if val.Kind() == reflect.Struct && !isScalar(t) {
 		for i := 0; i < val.NumField(); i++ {
 			field := val.Field(i)
 			if !field.CanSet() {
 				continue
 			}
 
-			fieldName := val.Type().Field(i).Name
-			err := loadRecursive(field, camelToUpperSnakeCase(fieldName, environmentKey))
+			structField := val.Type().Field(i)
+			if structField.Tag.Get(tagEnv) == "-" {
+				continue
+			}
+
+			key := camelToUpperSnakeCase(structField.Name, environmentKey)
+			if override, ok := structField.Tag.Lookup(tagEnv); ok && override != "" {
+				key = override
+			}
+
+			err := loadRecursive(field, key, structField.Tag, source, resolvers, missing)
 			if err != nil {
 				return err
 			}
 		}
+		return nil
 	}
 
-	// For slices and arrays, populate sparse structures. We don't allow specifying
-	// config differently per index.
-	if val.Kind() == reflect.Array || val.Kind() == reflect.Slice {
+	// For slices and arrays of structs, populate sparse structures: we don't allow specifying
+	// config differently per index. Slices of scalars are instead populated in full from a
+	// single separated environment value.
+	if (val.Kind() == reflect.Array || val.Kind() == reflect.Slice) && !isScalar(t) {
+		if val.Kind() == reflect.Slice && isScalar(t.Elem()) {
+			environmentValue, ok, err := lookupAndResolve(source, tag, environmentKey, resolvers)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				recordIfRequired(tag, environmentKey, missing)
+				return nil
+			}
+			return setScalarSlice(val, environmentValue, tag, environmentKey)
+		}
 		for j := 0; j < val.Len(); j++ {
-			err := loadRecursive(val.Index(j), environmentKey)
+			err := loadRecursive(val.Index(j), environmentKey, tag, source, resolvers, missing)
 			if err != nil {
 				return err
 			}
 		}
+		return nil
 	}
 
-	// leaf nodes
-	if environmentValue, ok := os.LookupEnv(environmentKey); ok {
-		switch val.Kind() {
-		case reflect.String:
-			val.SetString(environmentValue)
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-			n, err := strconv.Atoi(environmentValue)
+	// Maps of scalars are populated in full from a single separated environment value. Maps of
+	// structs or other maps aren't supported here.
+	if val.Kind() == reflect.Map {
+		if t.Key().Kind() == reflect.String && isScalar(t.Elem()) {
+			environmentValue, ok, err := lookupAndResolve(source, tag, environmentKey, resolvers)
 			if err != nil {
-				return fmt.Errorf("failed to parse environment key: %s to int: %w", environmentKey, err)
+				return err
 			}
-			val.SetInt(int64(n))
-		case reflect.Bool:
-			b, err := strconv.ParseBool(environmentValue)
-			if err != nil {
-				return fmt.Errorf("failed to parse environment key: %s to bool: %w", environmentKey, err)
+			if !ok {
+				recordIfRequired(tag, environmentKey, missing)
+				return nil
 			}
-			val.SetBool(b)
-		default:
-			return fmt.Errorf("failed to parse key: %s, unsupported field type: %s", environmentKey, val.Kind())
+			return setScalarMap(val, environmentValue, tag, environmentKey)
 		}
+		return nil
+	}
+
+	// leaf nodes
+	environmentValue, ok, err := lookupAndResolve(source, tag, environmentKey, resolvers)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		recordIfRequired(tag, environmentKey, missing)
+		return nil
+	}
+	return parseScalar(val, environmentValue, tag, environmentKey)
+}
+
+// lookupAndResolve resolves environmentKey via lookupValue and, if the field carries an
+// envSource/envFrom tag or the resolved value names a registered scheme (e.g. "vault://..."),
+// passes it through the matching [ValueResolver] before returning it.
+func lookupAndResolve(source Source, tag reflect.StructTag, environmentKey string, resolvers map[string]ValueResolver) (string, bool, error) {
+	environmentValue, ok := lookupValue(source, tag, environmentKey)
+	if !ok {
+		return "", false, nil
+	}
+	resolved, err := resolveValue(environmentValue, tag, resolvers)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to resolve environment key: %s: %w", environmentKey, err)
+	}
+	return resolved, true, nil
+}
+
+// lookupValue resolves environmentKey against source, falling back to the field's envDefault tag
+// if present.
+func lookupValue(source Source, tag reflect.StructTag, environmentKey string) (string, bool) {
+	if v, ok := source.Lookup(environmentKey); ok {
+		return v, true
+	}
+	if def, ok := tag.Lookup(tagEnvDefault); ok {
+		return def, true
+	}
+	return "", false
+}
+
+// recordIfRequired appends a missing-field error when the field is tagged envRequired:"true".
+func recordIfRequired(tag reflect.StructTag, environmentKey string, missing *[]error) {
+	if required, _ := strconv.ParseBool(tag.Get(tagEnvRequired)); required {
+		*missing = append(*missing, fmt.Errorf("required environment key not set: %s", environmentKey))
 	}
-	return nil
 }
 
 // camelToUpperSnakeCase converts a string in camel case to upper snake case
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"errors"
 	"os"
 	"strconv"
 	"strings"
@@ -189,6 +190,94 @@ func Test_LoadFromEnvironment(t *testing.T) {
 		}
 	})
 
+	t.Run("env tag overrides derived key", func(t *testing.T) {
+		config := struct {
+			Foo string `env:"CUSTOM_NAME"`
+		}{}
+		val := "bar"
+		os.Setenv("CUSTOM_NAME", val)
+		defer os.Clearenv()
+		err := LoadFromEnvironment(&config, "")
+		if err != nil {
+			t.Error(err)
+		}
+		if config.Foo != val {
+			t.Errorf("got: %s, want: %s", config.Foo, val)
+		}
+	})
+
+	t.Run("env tag of dash skips field", func(t *testing.T) {
+		config := struct {
+			Foo string `env:"-"`
+		}{}
+		os.Setenv("FOO", "bar")
+		defer os.Clearenv()
+		err := LoadFromEnvironment(&config, "")
+		if err != nil {
+			t.Error(err)
+		}
+		if config.Foo != "" {
+			t.Errorf("got: %s, want empty string", config.Foo)
+		}
+	})
+
+	t.Run("envDefault seeds value when unset", func(t *testing.T) {
+		config := struct {
+			Foo string `envDefault:"baz"`
+		}{}
+		err := LoadFromEnvironment(&config, "")
+		if err != nil {
+			t.Error(err)
+		}
+		if config.Foo != "baz" {
+			t.Errorf("got: %s, want: baz", config.Foo)
+		}
+	})
+
+	t.Run("envRequired returns error when unset", func(t *testing.T) {
+		config := struct {
+			Foo string `envRequired:"true"`
+		}{}
+		err := LoadFromEnvironment(&config, "")
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if !strings.Contains(err.Error(), "required environment key not set: FOO") {
+			t.Errorf("error message did not match expected format: %s", err)
+		}
+	})
+
+	t.Run("envRequired does not error when envDefault present", func(t *testing.T) {
+		config := struct {
+			Foo string `envRequired:"true" envDefault:"baz"`
+		}{}
+		err := LoadFromEnvironment(&config, "")
+		if err != nil {
+			t.Error(err)
+		}
+		if config.Foo != "baz" {
+			t.Errorf("got: %s, want: baz", config.Foo)
+		}
+	})
+
+	t.Run("multiple missing required fields are aggregated", func(t *testing.T) {
+		config := struct {
+			Foo string `envRequired:"true"`
+			Bar string `envRequired:"true"`
+		}{}
+		err := LoadFromEnvironment(&config, "")
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		var reqErr *RequiredFieldsError
+		if !errors.As(err, &reqErr) {
+			t.Fatalf("expected *RequiredFieldsError, got: %T", err)
+		}
+		if len(reqErr.Errs) != 2 {
+			t.Errorf("got: %d errors, want: 2", len(reqErr.Errs))
+		}
+	})
+
 	t.Run("nested slice of struct", func(t *testing.T) {
 		val := "qux"
 		config := struct {
@@ -0,0 +1,152 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+)
+
+// applyEnvironmentOverrides is the second pass described on [Load]: loadRecursive only ever sets
+// fields that already exist in the input struct, which means a map field can never gain entries
+// the input doesn't already have. This pass scans source for every key under prefix, and for each
+// one not consumed by a scalar map (see setScalarMap) walks the remaining path segments into any
+// map field it finds along the way - auto-creating map[string]interface{} nodes, and typed values
+// at typed maps.
+//
+// Only sources implementing [KeyEnumerator] participate; others are silently skipped. Struct
+// field names containing "_" are ambiguous against this underscore-delimited path scheme and
+// aren't supported.
+func applyEnvironmentOverrides(val reflect.Value, prefix string, source Source) error {
+	enumerator, ok := source.(KeyEnumerator)
+	if !ok {
+		return nil
+	}
+
+	for _, key := range enumerator.Keys() {
+		if prefix != "" {
+			if key == prefix || !strings.HasPrefix(key, prefix+"_") {
+				continue
+			}
+		}
+		remainder := strings.TrimPrefix(strings.TrimPrefix(key, prefix), "_")
+		if remainder == "" {
+			continue
+		}
+
+		value, ok := source.Lookup(key)
+		if !ok {
+			continue
+		}
+
+		if err := setEnvironmentPath(val, strings.Split(remainder, "_"), value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setEnvironmentPath walks val, a struct or map, following segments until it reaches a map field,
+// which it then populates via setMapPath. Structs without a field matching the leading segments,
+// and non-struct/map leaves, are left untouched - concrete fields are already handled by the
+// primary walk in loadRecursive. Field matching honors the same env tag as loadRecursive: a
+// field tagged `env:"-"` is skipped, and an `env:"NAME"` override replaces the derived key (NAME
+// may itself span multiple segments, e.g. "CUSTOM_STORAGE").
+func setEnvironmentPath(val reflect.Value, segments []string, value string) error {
+	if len(segments) == 0 {
+		return nil
+	}
+
+	switch val.Kind() {
+	case reflect.Struct:
+		for i := 0; i < val.NumField(); i++ {
+			field := val.Field(i)
+			if !field.CanSet() {
+				continue
+			}
+
+			structField := val.Type().Field(i)
+			if structField.Tag.Get(tagEnv) == "-" {
+				continue
+			}
+
+			key := camelToUpperSnakeCase(structField.Name, "")
+			if override, ok := structField.Tag.Lookup(tagEnv); ok && override != "" {
+				key = override
+			}
+
+			keySegments := strings.Split(key, "_")
+			if len(segments) < len(keySegments) || strings.Join(segments[:len(keySegments)], "_") != key {
+				continue
+			}
+			return setEnvironmentPath(field, segments[len(keySegments):], value)
+		}
+		return nil
+	case reflect.Map:
+		return setMapPath(val, segments, value)
+	default:
+		return nil
+	}
+}
+
+var anyMapType = reflect.TypeOf(map[string]any{})
+
+// setMapPath assigns value at the path described by segments within val, a settable map whose
+// key type is string. The first segment (lower-cased) is used as the map key; remaining segments
+// descend into nested maps, auto-creating map[string]interface{} nodes where the element type is
+// interface{}, and typed maps where the element type is itself a map.
+func setMapPath(val reflect.Value, segments []string, value string) error {
+	mapType := val.Type()
+	if mapType.Key().Kind() != reflect.String {
+		return nil
+	}
+	if val.IsNil() {
+		val.Set(reflect.MakeMap(mapType))
+	}
+
+	key := reflect.ValueOf(strings.ToLower(segments[0])).Convert(mapType.Key())
+	remaining := segments[1:]
+	elemType := mapType.Elem()
+
+	switch {
+	case elemType.Kind() == reflect.Interface && len(remaining) == 0:
+		val.SetMapIndex(key, reflect.ValueOf(value))
+		return nil
+
+	case elemType.Kind() == reflect.Interface:
+		nested := reflect.MakeMap(anyMapType)
+		if existing := val.MapIndex(key); existing.IsValid() {
+			if inner, ok := existing.Interface().(map[string]any); ok {
+				nested = reflect.ValueOf(inner)
+			}
+		}
+		if err := setMapPath(nested, remaining, value); err != nil {
+			return err
+		}
+		val.SetMapIndex(key, nested)
+		return nil
+
+	case elemType.Kind() == reflect.Map:
+		if len(remaining) == 0 {
+			return nil
+		}
+		nested := reflect.New(elemType).Elem()
+		if existing := val.MapIndex(key); existing.IsValid() {
+			nested.Set(existing)
+		}
+		if err := setMapPath(nested, remaining, value); err != nil {
+			return err
+		}
+		val.SetMapIndex(key, nested)
+		return nil
+
+	default:
+		if len(remaining) != 0 {
+			return nil
+		}
+		elemVal := reflect.New(elemType).Elem()
+		if err := parseScalar(elemVal, value, "", key.String()); err != nil {
+			return err
+		}
+		val.SetMapIndex(key, elemVal)
+		return nil
+	}
+}
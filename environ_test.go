@@ -0,0 +1,87 @@
+package config
+
+import "testing"
+
+func Test_Load_EnvironmentDrivenMaps(t *testing.T) {
+	t.Run("typed nested map gains unknown keys", func(t *testing.T) {
+		config := struct {
+			Storage map[string]map[string]string
+		}{}
+		source := MapSource{"APP_STORAGE_S3_BUCKET": "foo"}
+		if err := Load(&config, WithPrefix("APP"), WithSources(source)); err != nil {
+			t.Fatal(err)
+		}
+		if got := config.Storage["s3"]["bucket"]; got != "foo" {
+			t.Errorf("got: %s, want: foo", got)
+		}
+	})
+
+	t.Run("typed flat map gains unknown keys", func(t *testing.T) {
+		config := struct {
+			Tags map[string]string
+		}{}
+		source := MapSource{"TAGS_COLOR": "red"}
+		if err := Load(&config, WithSources(source)); err != nil {
+			t.Fatal(err)
+		}
+		if got := config.Tags["color"]; got != "red" {
+			t.Errorf("got: %s, want: red", got)
+		}
+	})
+
+	t.Run("map[string]interface{} is auto-created and nested", func(t *testing.T) {
+		config := struct {
+			Extra map[string]interface{}
+		}{}
+		source := MapSource{"EXTRA_A_B_C": "val"}
+		if err := Load(&config, WithSources(source)); err != nil {
+			t.Fatal(err)
+		}
+		a, ok := config.Extra["a"].(map[string]any)
+		if !ok {
+			t.Fatalf("got: %T, want map[string]any", config.Extra["a"])
+		}
+		b, ok := a["b"].(map[string]any)
+		if !ok {
+			t.Fatalf("got: %T, want map[string]any", a["b"])
+		}
+		if b["c"] != "val" {
+			t.Errorf("got: %v, want: val", b["c"])
+		}
+	})
+
+	t.Run("env tag override is honored instead of the derived key", func(t *testing.T) {
+		config := struct {
+			Storage map[string]string `env:"CUSTOM_STORAGE"`
+		}{}
+		source := MapSource{"CUSTOM_STORAGE_COLOR": "blue"}
+		if err := Load(&config, WithSources(source)); err != nil {
+			t.Fatal(err)
+		}
+		if got := config.Storage["color"]; got != "blue" {
+			t.Errorf("got: %s, want: blue", got)
+		}
+	})
+
+	t.Run("env dash tag excludes the field from dynamic map population", func(t *testing.T) {
+		config := struct {
+			Storage map[string]string `env:"-"`
+		}{}
+		source := MapSource{"STORAGE_COLOR": "blue"}
+		if err := Load(&config, WithSources(source)); err != nil {
+			t.Fatal(err)
+		}
+		if len(config.Storage) != 0 {
+			t.Errorf("expected Storage to stay empty, got: %v", config.Storage)
+		}
+	})
+
+	t.Run("scanning the real process environment doesn't error", func(t *testing.T) {
+		config := struct {
+			Tags map[string]string
+		}{}
+		if err := Load(&config, WithPrefix("CONFIG_ENVIRON_TEST"), WithSources(EnvSource{})); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
@@ -0,0 +1,199 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// DotEnvSource resolves values parsed from a ".env" style file: KEY=VALUE lines, blank lines and
+// "#" comments ignored, with optional "export " prefixes and single- or double-quoted values.
+// Double-quoted values support the usual Go escape sequences.
+type DotEnvSource struct {
+	values map[string]string
+}
+
+// LoadDotEnv reads and parses the dotenv file at path.
+func LoadDotEnv(path string) (DotEnvSource, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return DotEnvSource{}, fmt.Errorf("failed to read dotenv file: %s: %w", path, err)
+	}
+	values, err := parseDotEnv(string(data))
+	if err != nil {
+		return DotEnvSource{}, fmt.Errorf("failed to parse dotenv file: %s: %w", path, err)
+	}
+	return DotEnvSource{values: values}, nil
+}
+
+// Lookup implements [Source].
+func (s DotEnvSource) Lookup(key string) (string, bool) {
+	v, ok := s.values[key]
+	return v, ok
+}
+
+func parseDotEnv(data string) (map[string]string, error) {
+	values := make(map[string]string)
+	for i, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, rawValue, found := strings.Cut(line, "=")
+		if !found {
+			return nil, fmt.Errorf("malformed line %d: %q", i+1, line)
+		}
+		key = strings.TrimSpace(key)
+		rawValue = strings.TrimSpace(rawValue)
+
+		switch {
+		case len(rawValue) >= 2 && strings.HasPrefix(rawValue, `"`) && strings.HasSuffix(rawValue, `"`):
+			unquoted, err := strconv.Unquote(rawValue)
+			if err != nil {
+				return nil, fmt.Errorf("malformed quoted value on line %d: %w", i+1, err)
+			}
+			values[key] = unquoted
+		case len(rawValue) >= 2 && strings.HasPrefix(rawValue, "'") && strings.HasSuffix(rawValue, "'"):
+			values[key] = rawValue[1 : len(rawValue)-1]
+		default:
+			if idx := strings.Index(rawValue, " #"); idx >= 0 {
+				rawValue = strings.TrimSpace(rawValue[:idx])
+			}
+			values[key] = rawValue
+		}
+	}
+	return values, nil
+}
+
+// YAMLFileSource resolves values from a YAML document, flattened into the same underscore-joined
+// upper-case key scheme as [JSONFileSource], e.g. "foo:\n  bar: 1" resolves key "FOO_BAR". Only
+// nested mappings and scalar values are supported; sequences aren't addressable in this scheme and
+// are ignored.
+type YAMLFileSource struct {
+	values map[string]string
+}
+
+// LoadYAMLFile reads and flattens the YAML document at path.
+func LoadYAMLFile(path string) (YAMLFileSource, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return YAMLFileSource{}, fmt.Errorf("failed to read yaml config file: %s: %w", path, err)
+	}
+	values, err := parseYAML(string(data))
+	if err != nil {
+		return YAMLFileSource{}, fmt.Errorf("failed to parse yaml config file: %s: %w", path, err)
+	}
+	return YAMLFileSource{values: values}, nil
+}
+
+// Lookup implements [Source].
+func (s YAMLFileSource) Lookup(key string) (string, bool) {
+	v, ok := s.values[key]
+	return v, ok
+}
+
+// yamlScope tracks one level of a YAML mapping's indentation while parseYAML walks the document
+// line by line.
+type yamlScope struct {
+	indent int
+	prefix string
+}
+
+func parseYAML(data string) (map[string]string, error) {
+	values := make(map[string]string)
+	stack := []yamlScope{{indent: -1}}
+
+	for i, rawLine := range strings.Split(data, "\n") {
+		line := strings.TrimRight(rawLine, "\r")
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "---") {
+			continue
+		}
+		// Sequence entries aren't addressable by key in this scheme; skip without touching the
+		// scope stack, which is keyed off indentation of mapping entries only.
+		if strings.HasPrefix(trimmed, "- ") || trimmed == "-" {
+			continue
+		}
+
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		for len(stack) > 1 && stack[len(stack)-1].indent >= indent {
+			stack = stack[:len(stack)-1]
+		}
+		prefix := stack[len(stack)-1].prefix
+
+		key, rawValue, found := strings.Cut(trimmed, ":")
+		if !found {
+			return nil, fmt.Errorf("malformed line %d: %q", i+1, trimmed)
+		}
+		key = camelToUpperSnakeCase(strings.TrimSpace(key), prefix)
+		rawValue = strings.TrimSpace(rawValue)
+
+		if rawValue == "" {
+			stack = append(stack, yamlScope{indent: indent, prefix: key})
+			continue
+		}
+
+		switch {
+		case len(rawValue) >= 2 && strings.HasPrefix(rawValue, `"`) && strings.HasSuffix(rawValue, `"`):
+			unquoted, err := strconv.Unquote(rawValue)
+			if err != nil {
+				return nil, fmt.Errorf("malformed quoted value on line %d: %w", i+1, err)
+			}
+			values[key] = unquoted
+		case len(rawValue) >= 2 && strings.HasPrefix(rawValue, "'") && strings.HasSuffix(rawValue, "'"):
+			values[key] = rawValue[1 : len(rawValue)-1]
+		default:
+			if idx := strings.Index(rawValue, " #"); idx >= 0 {
+				rawValue = strings.TrimSpace(rawValue[:idx])
+			}
+			values[key] = rawValue
+		}
+	}
+	return values, nil
+}
+
+// JSONFileSource resolves values from a JSON document, flattened into the same underscore-joined
+// upper-case key scheme [LoadFromEnvironment] derives from struct field names, e.g.
+// {"foo": {"bar": 1}} resolves key "FOO_BAR". JSON arrays aren't addressable in this scheme and
+// are ignored.
+type JSONFileSource struct {
+	values map[string]string
+}
+
+// LoadJSONFile reads and flattens the JSON document at path.
+func LoadJSONFile(path string) (JSONFileSource, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return JSONFileSource{}, fmt.Errorf("failed to read json config file: %s: %w", path, err)
+	}
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return JSONFileSource{}, fmt.Errorf("failed to parse json config file: %s: %w", path, err)
+	}
+	values := make(map[string]string)
+	flattenJSON("", doc, values)
+	return JSONFileSource{values: values}, nil
+}
+
+// Lookup implements [Source].
+func (s JSONFileSource) Lookup(key string) (string, bool) {
+	v, ok := s.values[key]
+	return v, ok
+}
+
+func flattenJSON(prefix string, v any, out map[string]string) {
+	switch val := v.(type) {
+	case map[string]any:
+		for k, child := range val {
+			flattenJSON(camelToUpperSnakeCase(k, prefix), child, out)
+		}
+	case []any, nil:
+		// Arrays and nulls aren't addressable by key in this scheme; leave unset.
+	default:
+		out[prefix] = fmt.Sprint(val)
+	}
+}
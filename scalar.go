@@ -0,0 +1,183 @@
+package config
+
+import (
+	"encoding"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	textUnmarshalerType   = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	binaryUnmarshalerType = reflect.TypeOf((*encoding.BinaryUnmarshaler)(nil)).Elem()
+	durationType          = reflect.TypeOf(time.Duration(0))
+	timeType              = reflect.TypeOf(time.Time{})
+	byteSliceType         = reflect.TypeOf([]byte(nil))
+	urlType               = reflect.TypeOf(url.URL{})
+)
+
+// isScalar reports whether t can be populated directly from a single environment variable value,
+// as opposed to requiring further struct, slice, or map traversal.
+func isScalar(t reflect.Type) bool {
+	switch t {
+	case durationType, timeType, byteSliceType:
+		return true
+	}
+	if t.Kind() == reflect.Ptr && t.Elem() == urlType {
+		return true
+	}
+	if reflect.PtrTo(t).Implements(textUnmarshalerType) || reflect.PtrTo(t).Implements(binaryUnmarshalerType) {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// parseScalar parses environmentValue and assigns it to val. tag carries field-level modifiers
+// such as envLayout, consulted when val is a time.Time.
+func parseScalar(val reflect.Value, environmentValue string, tag reflect.StructTag, environmentKey string) error {
+	t := val.Type()
+
+	// time.Time implements encoding.TextUnmarshaler itself, hardcoded to RFC3339, which would
+	// otherwise shadow the envLayout-aware handling below; special-case it before the generic
+	// TextUnmarshaler/BinaryUnmarshaler check.
+	if t == timeType {
+		layout := tag.Get(tagEnvLayout)
+		if layout == "" {
+			layout = time.RFC3339
+		}
+		parsed, err := time.Parse(layout, environmentValue)
+		if err != nil {
+			return fmt.Errorf("failed to parse environment key: %s to time: %w", environmentKey, err)
+		}
+		val.Set(reflect.ValueOf(parsed))
+		return nil
+	}
+
+	if val.CanAddr() {
+		addr := val.Addr().Interface()
+		if u, ok := addr.(encoding.TextUnmarshaler); ok {
+			if err := u.UnmarshalText([]byte(environmentValue)); err != nil {
+				return fmt.Errorf("failed to parse environment key: %s: %w", environmentKey, err)
+			}
+			return nil
+		}
+		if u, ok := addr.(encoding.BinaryUnmarshaler); ok {
+			if err := u.UnmarshalBinary([]byte(environmentValue)); err != nil {
+				return fmt.Errorf("failed to parse environment key: %s: %w", environmentKey, err)
+			}
+			return nil
+		}
+	}
+
+	switch {
+	case t == durationType:
+		d, err := time.ParseDuration(environmentValue)
+		if err != nil {
+			return fmt.Errorf("failed to parse environment key: %s to duration: %w", environmentKey, err)
+		}
+		val.SetInt(int64(d))
+		return nil
+	case t == byteSliceType:
+		b, err := base64.StdEncoding.DecodeString(environmentValue)
+		if err != nil {
+			return fmt.Errorf("failed to parse environment key: %s to []byte: %w", environmentKey, err)
+		}
+		val.SetBytes(b)
+		return nil
+	case t.Kind() == reflect.Ptr && t.Elem() == urlType:
+		u, err := url.Parse(environmentValue)
+		if err != nil {
+			return fmt.Errorf("failed to parse environment key: %s to url: %w", environmentKey, err)
+		}
+		val.Set(reflect.ValueOf(u))
+		return nil
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		val.SetString(environmentValue)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(environmentValue, 10, 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse environment key: %s to int: %w", environmentKey, err)
+		}
+		val.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(environmentValue, 10, 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse environment key: %s to uint: %w", environmentKey, err)
+		}
+		val.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(environmentValue, 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse environment key: %s to float: %w", environmentKey, err)
+		}
+		val.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(environmentValue)
+		if err != nil {
+			return fmt.Errorf("failed to parse environment key: %s to bool: %w", environmentKey, err)
+		}
+		val.SetBool(b)
+	default:
+		return fmt.Errorf("failed to parse key: %s, unsupported field type: %s", environmentKey, t.Kind())
+	}
+	return nil
+}
+
+// setScalarSlice splits environmentValue on the field's envSeparator (default ",") and parses
+// each element into a freshly allocated slice of val's element type.
+func setScalarSlice(val reflect.Value, environmentValue string, tag reflect.StructTag, environmentKey string) error {
+	sep := tag.Get(tagEnvSeparator)
+	if sep == "" {
+		sep = ","
+	}
+	parts := strings.Split(environmentValue, sep)
+	out := reflect.MakeSlice(val.Type(), len(parts), len(parts))
+	for i, part := range parts {
+		if err := parseScalar(out.Index(i), strings.TrimSpace(part), tag, environmentKey); err != nil {
+			return err
+		}
+	}
+	val.Set(out)
+	return nil
+}
+
+// setScalarMap splits environmentValue into "key:value" entries separated by the field's
+// envSeparator (default ",") and parses each into a freshly allocated map of val's type.
+func setScalarMap(val reflect.Value, environmentValue string, tag reflect.StructTag, environmentKey string) error {
+	sep := tag.Get(tagEnvSeparator)
+	if sep == "" {
+		sep = ","
+	}
+	out := reflect.MakeMap(val.Type())
+	for _, entry := range strings.Split(environmentValue, sep) {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		k, v, found := strings.Cut(entry, ":")
+		if !found {
+			return fmt.Errorf("failed to parse environment key: %s, malformed map entry: %s", environmentKey, entry)
+		}
+		mapValue := reflect.New(val.Type().Elem()).Elem()
+		if err := parseScalar(mapValue, v, tag, environmentKey); err != nil {
+			return err
+		}
+		out.SetMapIndex(reflect.ValueOf(k).Convert(val.Type().Key()), mapValue)
+	}
+	val.Set(out)
+	return nil
+}
@@ -0,0 +1,161 @@
+package config
+
+import (
+	"net"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+)
+
+func Test_LoadFromEnvironment_ScalarTypes(t *testing.T) {
+	t.Run("time.Duration field", func(t *testing.T) {
+		config := struct{ Foo time.Duration }{}
+		os.Setenv("FOO", "1h30m")
+		defer os.Clearenv()
+		if err := LoadFromEnvironment(&config, ""); err != nil {
+			t.Fatal(err)
+		}
+		if config.Foo != 90*time.Minute {
+			t.Errorf("got: %s, want: %s", config.Foo, 90*time.Minute)
+		}
+	})
+
+	t.Run("time.Time field defaults to RFC3339", func(t *testing.T) {
+		config := struct{ Foo time.Time }{}
+		os.Setenv("FOO", "2024-01-02T15:04:05Z")
+		defer os.Clearenv()
+		if err := LoadFromEnvironment(&config, ""); err != nil {
+			t.Fatal(err)
+		}
+		want, _ := time.Parse(time.RFC3339, "2024-01-02T15:04:05Z")
+		if !config.Foo.Equal(want) {
+			t.Errorf("got: %s, want: %s", config.Foo, want)
+		}
+	})
+
+	t.Run("time.Time field honors envLayout tag", func(t *testing.T) {
+		config := struct {
+			Foo time.Time `envLayout:"2006-01-02"`
+		}{}
+		os.Setenv("FOO", "2024-01-02")
+		defer os.Clearenv()
+		if err := LoadFromEnvironment(&config, ""); err != nil {
+			t.Fatal(err)
+		}
+		want, _ := time.Parse("2006-01-02", "2024-01-02")
+		if !config.Foo.Equal(want) {
+			t.Errorf("got: %s, want: %s", config.Foo, want)
+		}
+	})
+
+	t.Run("*url.URL field", func(t *testing.T) {
+		config := struct{ Foo *url.URL }{}
+		os.Setenv("FOO", "https://example.com/path")
+		defer os.Clearenv()
+		if err := LoadFromEnvironment(&config, ""); err != nil {
+			t.Fatal(err)
+		}
+		if config.Foo == nil || config.Foo.String() != "https://example.com/path" {
+			t.Errorf("got: %v, want: https://example.com/path", config.Foo)
+		}
+	})
+
+	t.Run("net.IP field", func(t *testing.T) {
+		config := struct{ Foo net.IP }{}
+		os.Setenv("FOO", "127.0.0.1")
+		defer os.Clearenv()
+		if err := LoadFromEnvironment(&config, ""); err != nil {
+			t.Fatal(err)
+		}
+		if !config.Foo.Equal(net.ParseIP("127.0.0.1")) {
+			t.Errorf("got: %s, want: 127.0.0.1", config.Foo)
+		}
+	})
+
+	t.Run("uint field", func(t *testing.T) {
+		config := struct{ Foo uint }{}
+		os.Setenv("FOO", "42")
+		defer os.Clearenv()
+		if err := LoadFromEnvironment(&config, ""); err != nil {
+			t.Fatal(err)
+		}
+		if config.Foo != 42 {
+			t.Errorf("got: %d, want: 42", config.Foo)
+		}
+	})
+
+	t.Run("float field", func(t *testing.T) {
+		config := struct{ Foo float64 }{}
+		os.Setenv("FOO", "3.14")
+		defer os.Clearenv()
+		if err := LoadFromEnvironment(&config, ""); err != nil {
+			t.Fatal(err)
+		}
+		if config.Foo != 3.14 {
+			t.Errorf("got: %f, want: 3.14", config.Foo)
+		}
+	})
+
+	t.Run("[]byte field decodes base64", func(t *testing.T) {
+		config := struct{ Foo []byte }{}
+		os.Setenv("FOO", "aGVsbG8=")
+		defer os.Clearenv()
+		if err := LoadFromEnvironment(&config, ""); err != nil {
+			t.Fatal(err)
+		}
+		if string(config.Foo) != "hello" {
+			t.Errorf("got: %s, want: hello", config.Foo)
+		}
+	})
+
+	t.Run("slice of scalars uses default comma separator", func(t *testing.T) {
+		config := struct{ Foo []int }{}
+		os.Setenv("FOO", "1,2,3")
+		defer os.Clearenv()
+		if err := LoadFromEnvironment(&config, ""); err != nil {
+			t.Fatal(err)
+		}
+		want := []int{1, 2, 3}
+		if len(config.Foo) != len(want) {
+			t.Fatalf("got: %v, want: %v", config.Foo, want)
+		}
+		for i := range want {
+			if config.Foo[i] != want[i] {
+				t.Errorf("got: %v, want: %v", config.Foo, want)
+			}
+		}
+	})
+
+	t.Run("slice of scalars honors envSeparator tag", func(t *testing.T) {
+		config := struct {
+			Foo []string `envSeparator:"|"`
+		}{}
+		os.Setenv("FOO", "a|b|c")
+		defer os.Clearenv()
+		if err := LoadFromEnvironment(&config, ""); err != nil {
+			t.Fatal(err)
+		}
+		want := []string{"a", "b", "c"}
+		if len(config.Foo) != len(want) {
+			t.Fatalf("got: %v, want: %v", config.Foo, want)
+		}
+		for i := range want {
+			if config.Foo[i] != want[i] {
+				t.Errorf("got: %v, want: %v", config.Foo, want)
+			}
+		}
+	})
+
+	t.Run("map of scalars", func(t *testing.T) {
+		config := struct{ Foo map[string]int }{}
+		os.Setenv("FOO", "a:1,b:2")
+		defer os.Clearenv()
+		if err := LoadFromEnvironment(&config, ""); err != nil {
+			t.Fatal(err)
+		}
+		if config.Foo["a"] != 1 || config.Foo["b"] != 2 {
+			t.Errorf("got: %v, want: map[a:1 b:2]", config.Foo)
+		}
+	})
+}
@@ -0,0 +1,151 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Struct tags for secret handling: envSource and envFrom are consumed by [loadRecursive], secret
+// by [Redact].
+const (
+	// tagEnvSource (or its alias tagEnvFrom) names the scheme a [ValueResolver] is registered
+	// under and routes the field's resolved environment value through it before type conversion.
+	tagEnvSource = "envSource"
+	tagEnvFrom   = "envFrom"
+	// tagSecret marks a field so [Redact] replaces its value with a fixed placeholder.
+	tagSecret = "secret"
+)
+
+// redactedPlaceholder is written by [Redact] in place of a secret-tagged field's real value.
+const redactedPlaceholder = "[REDACTED]"
+
+// ValueResolver resolves a raw environment value into the value that should actually populate a
+// field, indirecting through an external system such as a Vault server or an SSM parameter
+// store. Register one with [WithValueResolver] under the scheme name a field's envSource (or
+// envFrom) tag refers to, e.g. envSource:"vault" routes through the resolver registered as
+// "vault". A field isn't required to name a scheme explicitly: if its raw value looks like
+// "scheme://..." and a resolver is registered under that scheme, it's used automatically.
+type ValueResolver interface {
+	Resolve(raw string) (string, error)
+}
+
+// FileValueResolver implements the "docker secrets / Kubernetes secret volume" pattern: raw is
+// treated as a filesystem path, and the (whitespace-trimmed) contents of that file become the
+// field's real value, e.g. a field tagged `env:"DB_PASSWORD_FILE" envSource:"file"` populated
+// from DB_PASSWORD_FILE=/run/secrets/db reads the secret out of /run/secrets/db. It's registered
+// under the "file" scheme by default; a leading "file://" is stripped from raw before it's read.
+type FileValueResolver struct{}
+
+// Resolve implements [ValueResolver].
+func (FileValueResolver) Resolve(raw string) (string, error) {
+	data, err := os.ReadFile(strings.TrimPrefix(raw, "file://"))
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret file: %s: %w", raw, err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// resolveValue routes raw through a [ValueResolver] when the field's tag names one explicitly
+// (via envSource or envFrom), or when raw itself names a registered scheme (e.g.
+// "vault://path#field"). Otherwise raw is returned unchanged.
+func resolveValue(raw string, tag reflect.StructTag, resolvers map[string]ValueResolver) (string, error) {
+	if scheme, ok := tag.Lookup(tagEnvSource); ok {
+		return applyResolver(scheme, raw, resolvers, true)
+	}
+	if scheme, ok := tag.Lookup(tagEnvFrom); ok {
+		return applyResolver(scheme, raw, resolvers, true)
+	}
+	if idx := strings.Index(raw, "://"); idx > 0 {
+		return applyResolver(raw[:idx], raw, resolvers, false)
+	}
+	return raw, nil
+}
+
+// applyResolver resolves raw through the resolver registered under scheme. When required is true
+// (the scheme was named explicitly by a tag) an unregistered scheme is an error; otherwise raw is
+// left untouched, since an unregistered "scheme://" prefix may just be an ordinary value such as
+// a URL.
+func applyResolver(scheme, raw string, resolvers map[string]ValueResolver, required bool) (string, error) {
+	resolver, ok := resolvers[scheme]
+	if !ok {
+		if required {
+			return "", fmt.Errorf("no value resolver registered for scheme: %s", scheme)
+		}
+		return raw, nil
+	}
+	resolved, err := resolver.Resolve(raw)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve value: %w", err)
+	}
+	return resolved, nil
+}
+
+// Redact walks v the same way [LoadFromEnvironment] walks it and returns a "KEY=value" dump, one
+// line per recognised environment variable (repeated once per element for a slice or array of
+// structs, whose elements share the same keys), safe to write to logs: fields tagged
+// `secret:"true"` have their value replaced with a fixed placeholder instead of being printed.
+func Redact(v any) string {
+	val := reflect.ValueOf(v)
+	for val.Kind() == reflect.Ptr {
+		val = val.Elem()
+	}
+
+	var sb strings.Builder
+	walkRedact(val, "", "", &sb)
+	return sb.String()
+}
+
+func walkRedact(val reflect.Value, environmentKey string, tag reflect.StructTag, sb *strings.Builder) {
+	t := val.Type()
+
+	if val.Kind() == reflect.Struct && !isScalar(t) {
+		for i := 0; i < t.NumField(); i++ {
+			structField := t.Field(i)
+			if structField.PkgPath != "" {
+				continue // unexported
+			}
+			if structField.Tag.Get(tagEnv) == "-" {
+				continue
+			}
+
+			key := camelToUpperSnakeCase(structField.Name, environmentKey)
+			if override, ok := structField.Tag.Lookup(tagEnv); ok && override != "" {
+				key = override
+			}
+
+			walkRedact(val.Field(i), key, structField.Tag, sb)
+		}
+		return
+	}
+
+	if (val.Kind() == reflect.Array || val.Kind() == reflect.Slice) && !isScalar(t) {
+		if val.Kind() == reflect.Slice && isScalar(t.Elem()) {
+			writeRedactLine(sb, environmentKey, val, tag)
+			return
+		}
+		for j := 0; j < val.Len(); j++ {
+			walkRedact(val.Index(j), environmentKey, tag, sb)
+		}
+		return
+	}
+
+	if val.Kind() == reflect.Map {
+		if t.Key().Kind() == reflect.String && isScalar(t.Elem()) {
+			writeRedactLine(sb, environmentKey, val, tag)
+		}
+		return
+	}
+
+	writeRedactLine(sb, environmentKey, val, tag)
+}
+
+func writeRedactLine(sb *strings.Builder, environmentKey string, val reflect.Value, tag reflect.StructTag) {
+	value := redactedPlaceholder
+	if secret, _ := strconv.ParseBool(tag.Get(tagSecret)); !secret {
+		value = fmt.Sprintf("%v", val.Interface())
+	}
+	fmt.Fprintf(sb, "%s=%s\n", environmentKey, value)
+}
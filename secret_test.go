@@ -0,0 +1,99 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_Load_SecretSources(t *testing.T) {
+	t.Run("envSource file reads the referenced file's contents", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "db_password")
+		if err := os.WriteFile(path, []byte("hunter2\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		config := struct {
+			Password string `env:"DB_PASSWORD_FILE" envSource:"file"`
+		}{}
+		source := MapSource{"DB_PASSWORD_FILE": path}
+		if err := Load(&config, WithSources(source)); err != nil {
+			t.Fatal(err)
+		}
+		if config.Password != "hunter2" {
+			t.Errorf("got: %q, want: %q", config.Password, "hunter2")
+		}
+	})
+
+	t.Run("envSource with an unregistered scheme errors", func(t *testing.T) {
+		config := struct {
+			Password string `envSource:"vault"`
+		}{}
+		source := MapSource{"PASSWORD": "vault://secret/db#password"}
+		err := Load(&config, WithSources(source))
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if !strings.Contains(err.Error(), "no value resolver registered for scheme: vault") {
+			t.Errorf("unexpected error: %s", err)
+		}
+	})
+
+	t.Run("custom ValueResolver is invoked for its scheme", func(t *testing.T) {
+		config := struct {
+			Password string
+		}{}
+		source := MapSource{"PASSWORD": "vault://secret/db#password"}
+		resolver := stubResolver{value: "hunter2"}
+		if err := Load(&config, WithSources(source), WithValueResolver("vault", resolver)); err != nil {
+			t.Fatal(err)
+		}
+		if config.Password != "hunter2" {
+			t.Errorf("got: %q, want: %q", config.Password, "hunter2")
+		}
+	})
+
+	t.Run("unregistered scheme without an explicit tag passes the raw value through", func(t *testing.T) {
+		config := struct {
+			Endpoint string
+		}{}
+		source := MapSource{"ENDPOINT": "https://example.com"}
+		if err := Load(&config, WithSources(source)); err != nil {
+			t.Fatal(err)
+		}
+		if config.Endpoint != "https://example.com" {
+			t.Errorf("got: %q, want: %q", config.Endpoint, "https://example.com")
+		}
+	})
+}
+
+type stubResolver struct {
+	value string
+}
+
+func (s stubResolver) Resolve(raw string) (string, error) {
+	return s.value, nil
+}
+
+func Test_Redact(t *testing.T) {
+	config := struct {
+		Name     string
+		Password string `secret:"true"`
+	}{
+		Name:     "svc",
+		Password: "hunter2",
+	}
+
+	out := Redact(&config)
+	if !strings.Contains(out, "NAME=svc") {
+		t.Errorf("missing Name line: %s", out)
+	}
+	if strings.Contains(out, "hunter2") {
+		t.Errorf("secret value leaked: %s", out)
+	}
+	if !strings.Contains(out, "PASSWORD=[REDACTED]") {
+		t.Errorf("missing redacted Password line: %s", out)
+	}
+}
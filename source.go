@@ -0,0 +1,136 @@
+package config
+
+import (
+	"os"
+	"strings"
+)
+
+// Source resolves a single configuration value by its derived environment-variable-style key.
+// It's the extension point [Load] uses to look beyond the process environment: a file, a
+// dotenv document, or an in-memory map for tests can all satisfy it.
+type Source interface {
+	Lookup(key string) (string, bool)
+}
+
+// KeyEnumerator is implemented by sources that can list every key they hold. [Load] uses it to
+// discover map entries that don't correspond to any field known ahead of time in the input
+// struct; sources that can't enumerate their keys simply don't participate in that discovery.
+type KeyEnumerator interface {
+	Keys() []string
+}
+
+// EnvSource resolves values from the process environment. It's the default (and only) source
+// used by [LoadFromEnvironment].
+type EnvSource struct{}
+
+// Lookup implements [Source].
+func (EnvSource) Lookup(key string) (string, bool) {
+	return os.LookupEnv(key)
+}
+
+// Keys implements [KeyEnumerator].
+func (EnvSource) Keys() []string {
+	environ := os.Environ()
+	keys := make([]string, 0, len(environ))
+	for _, kv := range environ {
+		if k, _, ok := strings.Cut(kv, "="); ok {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+// MapSource resolves values from an in-memory map, primarily useful for tests that want to avoid
+// touching the real process environment.
+type MapSource map[string]string
+
+// Lookup implements [Source].
+func (m MapSource) Lookup(key string) (string, bool) {
+	v, ok := m[key]
+	return v, ok
+}
+
+// Keys implements [KeyEnumerator].
+func (m MapSource) Keys() []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// sourceList consults its sources in order, returning the first match. Earlier sources take
+// precedence over later ones.
+type sourceList []Source
+
+// Lookup implements [Source].
+func (s sourceList) Lookup(key string) (string, bool) {
+	for _, src := range s {
+		if v, ok := src.Lookup(key); ok {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// Keys implements [KeyEnumerator], merging the keys of every enumerable source.
+func (s sourceList) Keys() []string {
+	seen := make(map[string]struct{})
+	var keys []string
+	for _, src := range s {
+		enumerator, ok := src.(KeyEnumerator)
+		if !ok {
+			continue
+		}
+		for _, k := range enumerator.Keys() {
+			if _, dup := seen[k]; dup {
+				continue
+			}
+			seen[k] = struct{}{}
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+// Option configures a call to [Load].
+type Option func(*loadOptions)
+
+type loadOptions struct {
+	prefix    string
+	sources   []Source
+	resolvers map[string]ValueResolver
+}
+
+// WithPrefix sets the prefix prepended to every derived environment key, equivalent to the
+// prefix argument to [LoadFromEnvironment].
+func WithPrefix(prefix string) Option {
+	return func(o *loadOptions) { o.prefix = prefix }
+}
+
+// WithSources sets the sources consulted when resolving a key, in precedence order: the first
+// source to report a value wins. e.g. WithSources(EnvSource{}, dotEnvSource, fileSource) lets the
+// environment override a dotenv file, which in turn overrides a config file. Without this option
+// (or [WithLookupOrder]), Load consults a single [EnvSource]; calling it with no sources leaves
+// Load with none.
+func WithSources(sources ...Source) Option {
+	return func(o *loadOptions) { o.sources = sources }
+}
+
+// WithLookupOrder is an alias for [WithSources]: it exists so that call sites reading as
+// "environment, then dotenv, then file" can spell precedence with a name that matches intent.
+func WithLookupOrder(sources ...Source) Option {
+	return WithSources(sources...)
+}
+
+// WithValueResolver registers resolver under scheme, in addition to the built-in "file" scheme.
+// A field tagged envSource:"vault" (or envFrom:"vault") is resolved through whatever resolver is
+// registered under "vault"; see [ValueResolver].
+func WithValueResolver(scheme string, resolver ValueResolver) Option {
+	return func(o *loadOptions) {
+		if o.resolvers == nil {
+			o.resolvers = map[string]ValueResolver{}
+		}
+		o.resolvers[scheme] = resolver
+	}
+}
@@ -0,0 +1,152 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_Load(t *testing.T) {
+	t.Run("defaults to environment source", func(t *testing.T) {
+		config := struct{ Foo string }{}
+		os.Setenv("FOO", "bar")
+		defer os.Clearenv()
+		if err := Load(&config); err != nil {
+			t.Fatal(err)
+		}
+		if config.Foo != "bar" {
+			t.Errorf("got: %s, want: bar", config.Foo)
+		}
+	})
+
+	t.Run("WithPrefix behaves like LoadFromEnvironment's prefix argument", func(t *testing.T) {
+		config := struct{ Foo string }{}
+		os.Setenv("APP_FOO", "bar")
+		defer os.Clearenv()
+		if err := Load(&config, WithPrefix("APP")); err != nil {
+			t.Fatal(err)
+		}
+		if config.Foo != "bar" {
+			t.Errorf("got: %s, want: bar", config.Foo)
+		}
+	})
+
+	t.Run("WithSources consults sources in precedence order", func(t *testing.T) {
+		config := struct{ Foo string }{}
+		high := MapSource{"FOO": "from-high"}
+		low := MapSource{"FOO": "from-low"}
+		if err := Load(&config, WithSources(high, low)); err != nil {
+			t.Fatal(err)
+		}
+		if config.Foo != "from-high" {
+			t.Errorf("got: %s, want: from-high", config.Foo)
+		}
+	})
+
+	t.Run("WithSources falls through to a lower-precedence source", func(t *testing.T) {
+		config := struct{ Foo string }{}
+		high := MapSource{}
+		low := MapSource{"FOO": "from-low"}
+		if err := Load(&config, WithSources(high, low)); err != nil {
+			t.Fatal(err)
+		}
+		if config.Foo != "from-low" {
+			t.Errorf("got: %s, want: from-low", config.Foo)
+		}
+	})
+
+	t.Run("WithLookupOrder is equivalent to WithSources", func(t *testing.T) {
+		config := struct{ Foo string }{}
+		if err := Load(&config, WithLookupOrder(MapSource{"FOO": "bar"})); err != nil {
+			t.Fatal(err)
+		}
+		if config.Foo != "bar" {
+			t.Errorf("got: %s, want: bar", config.Foo)
+		}
+	})
+}
+
+func Test_LoadDotEnv(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".env")
+	contents := "# a comment\n\nexport FOO=bar\nBAZ=\"quoted value\"\nQUX='single quoted'\n"
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	source, err := LoadDotEnv(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for key, want := range map[string]string{
+		"FOO": "bar",
+		"BAZ": "quoted value",
+		"QUX": "single quoted",
+	} {
+		got, ok := source.Lookup(key)
+		if !ok {
+			t.Errorf("key %s not found", key)
+		}
+		if got != want {
+			t.Errorf("key %s: got: %s, want: %s", key, got, want)
+		}
+	}
+}
+
+func Test_LoadYAMLFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	contents := "foo:\n  bar: baz\nqux: 1\nlist:\n  - a\n  - b\n"
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	source, err := LoadYAMLFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for key, want := range map[string]string{
+		"FOO_BAR": "baz",
+		"QUX":     "1",
+	} {
+		got, ok := source.Lookup(key)
+		if !ok {
+			t.Errorf("key %s not found", key)
+		}
+		if got != want {
+			t.Errorf("key %s: got: %s, want: %s", key, got, want)
+		}
+	}
+	if _, ok := source.Lookup("LIST"); ok {
+		t.Errorf("expected LIST (a sequence) to be unaddressable")
+	}
+}
+
+func Test_LoadJSONFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+	contents := `{"foo": {"bar": "baz"}, "qux": 1}`
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	source, err := LoadJSONFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for key, want := range map[string]string{
+		"FOO_BAR": "baz",
+		"QUX":     "1",
+	} {
+		got, ok := source.Lookup(key)
+		if !ok {
+			t.Errorf("key %s not found", key)
+		}
+		if got != want {
+			t.Errorf("key %s: got: %s, want: %s", key, got, want)
+		}
+	}
+}
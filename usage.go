@@ -0,0 +1,138 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+)
+
+// tagEnvDescription documents an environment variable for [Usage]; it has no effect on loading.
+const tagEnvDescription = "envDescription"
+
+// UsageEntry describes a single environment variable recognised while walking a config struct,
+// as reported by [Usage].
+type UsageEntry struct {
+	// Name is the derived (or env-tag overridden) environment variable name.
+	Name string
+	// Type is the Go type of the field the variable populates.
+	Type string
+	// Default is the value used when the variable is unset: the envDefault tag if present,
+	// otherwise the field's zero value.
+	Default string
+	// HasDefault reports whether Default came from an envDefault tag, as opposed to a zero value.
+	HasDefault bool
+	// Required reports whether the field carries envRequired:"true".
+	Required bool
+	// Description is the field's envDescription tag, if any.
+	Description string
+}
+
+// Usage walks v the same way [LoadFromEnvironment] walks it and writes a table of every
+// environment variable it recognises to w: name, type, default, required, and a description
+// sourced from an `envDescription:"..."` tag. v may be a struct or a pointer to one.
+func Usage(v any, prefix string, w io.Writer) error {
+	return UsageWithTemplate(v, prefix, w, defaultUsageTemplate)
+}
+
+// UsageString behaves like [Usage] but returns the table as a string.
+func UsageString(v any, prefix string) (string, error) {
+	var sb strings.Builder
+	if err := Usage(v, prefix, &sb); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// defaultUsageTemplate renders a tab-separated table; it's fed through a tabwriter by
+// UsageWithTemplate so columns line up regardless of value width.
+const defaultUsageTemplate = "VARIABLE\tTYPE\tDEFAULT\tREQUIRED\tDESCRIPTION\n" +
+	"{{range .}}{{.Name}}\t{{.Type}}\t{{.Default}}\t{{.Required}}\t{{.Description}}\n{{end}}"
+
+// UsageWithTemplate behaves like [Usage] but renders the walked [UsageEntry] slice through tmpl
+// (text/template syntax) instead of the built-in table. Output is passed through a tabwriter, so
+// tab-separated template output lines up into columns.
+func UsageWithTemplate(v any, prefix string, w io.Writer, tmpl string) error {
+	t, err := template.New("usage").Parse(tmpl)
+	if err != nil {
+		return fmt.Errorf("failed to parse usage template: %w", err)
+	}
+
+	val := reflect.ValueOf(v)
+	for val.Kind() == reflect.Ptr {
+		val = val.Elem()
+	}
+
+	var entries []UsageEntry
+	walkUsage(val, prefix, "", &entries)
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	if err := t.Execute(tw, entries); err != nil {
+		return fmt.Errorf("failed to render usage template: %w", err)
+	}
+	return tw.Flush()
+}
+
+func walkUsage(val reflect.Value, environmentKey string, tag reflect.StructTag, entries *[]UsageEntry) {
+	t := val.Type()
+
+	if val.Kind() == reflect.Struct && !isScalar(t) {
+		for i := 0; i < t.NumField(); i++ {
+			structField := t.Field(i)
+			if structField.PkgPath != "" {
+				continue // unexported
+			}
+			if structField.Tag.Get(tagEnv) == "-" {
+				continue
+			}
+
+			key := camelToUpperSnakeCase(structField.Name, environmentKey)
+			if override, ok := structField.Tag.Lookup(tagEnv); ok && override != "" {
+				key = override
+			}
+
+			walkUsage(val.Field(i), key, structField.Tag, entries)
+		}
+		return
+	}
+
+	if (val.Kind() == reflect.Array || val.Kind() == reflect.Slice) && !isScalar(t) {
+		if val.Kind() == reflect.Slice && isScalar(t.Elem()) {
+			*entries = append(*entries, newUsageEntry(val, environmentKey, tag))
+			return
+		}
+		// Slices of structs share one set of keys across every index; describe a single
+		// synthetic element rather than repeating the table once per populated index.
+		walkUsage(reflect.New(t.Elem()).Elem(), environmentKey, tag, entries)
+		return
+	}
+
+	if val.Kind() == reflect.Map {
+		if t.Key().Kind() == reflect.String && isScalar(t.Elem()) {
+			*entries = append(*entries, newUsageEntry(val, environmentKey, tag))
+		}
+		return
+	}
+
+	*entries = append(*entries, newUsageEntry(val, environmentKey, tag))
+}
+
+func newUsageEntry(val reflect.Value, environmentKey string, tag reflect.StructTag) UsageEntry {
+	def, hasDefault := tag.Lookup(tagEnvDefault)
+	if !hasDefault {
+		def = fmt.Sprintf("%v", reflect.Zero(val.Type()).Interface())
+	}
+	required, _ := strconv.ParseBool(tag.Get(tagEnvRequired))
+
+	return UsageEntry{
+		Name:        environmentKey,
+		Type:        val.Type().String(),
+		Default:     def,
+		HasDefault:  hasDefault,
+		Required:    required,
+		Description: tag.Get(tagEnvDescription),
+	}
+}
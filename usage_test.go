@@ -0,0 +1,107 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_Usage(t *testing.T) {
+	t.Run("reports name, type, default, required, and description", func(t *testing.T) {
+		config := struct {
+			Port int    `envDefault:"8080" envDescription:"HTTP listen port"`
+			Name string `envRequired:"true" envDescription:"service name"`
+		}{}
+		out, err := UsageString(&config, "APP")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(out, "APP_PORT") || !strings.Contains(out, "8080") || !strings.Contains(out, "HTTP listen port") {
+			t.Errorf("missing Port row: %s", out)
+		}
+		if !strings.Contains(out, "APP_NAME") || !strings.Contains(out, "true") || !strings.Contains(out, "service name") {
+			t.Errorf("missing Name row: %s", out)
+		}
+	})
+
+	t.Run("reports zero value as default when envDefault is absent", func(t *testing.T) {
+		config := struct{ Count int }{}
+		out, err := UsageString(&config, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(out, "COUNT") || !strings.Contains(out, "0") {
+			t.Errorf("missing Count row with zero-value default: %s", out)
+		}
+	})
+
+	t.Run("skips env dash tagged fields", func(t *testing.T) {
+		config := struct {
+			Secret string `env:"-"`
+		}{}
+		out, err := UsageString(&config, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(out, "SECRET") {
+			t.Errorf("expected SECRET to be excluded, got: %s", out)
+		}
+	})
+
+	t.Run("nested struct", func(t *testing.T) {
+		config := struct {
+			Database struct {
+				Host string
+			}
+		}{}
+		out, err := UsageString(&config, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(out, "DATABASE_HOST") {
+			t.Errorf("missing nested field row: %s", out)
+		}
+	})
+
+	t.Run("slice of struct reports one synthetic row per field", func(t *testing.T) {
+		config := struct {
+			Servers []struct {
+				Host string
+			}
+		}{}
+		out, err := UsageString(&config, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Count(out, "SERVERS_HOST") != 1 {
+			t.Errorf("expected exactly one SERVERS_HOST row, got: %s", out)
+		}
+	})
+
+	t.Run("slice and map of scalars each report one row", func(t *testing.T) {
+		config := struct {
+			Tags    []string
+			Aliases map[string]string
+		}{}
+		out, err := UsageString(&config, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Count(out, "TAGS") != 1 || strings.Count(out, "ALIASES") != 1 {
+			t.Errorf("missing slice/map rows: %s", out)
+		}
+	})
+}
+
+func Test_UsageWithTemplate(t *testing.T) {
+	config := struct {
+		Port int `envDefault:"8080"`
+	}{}
+	var sb strings.Builder
+	err := UsageWithTemplate(&config, "", &sb, "{{range .}}{{.Name}}={{.Default}}\n{{end}}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sb.String() != "PORT=8080\n" {
+		t.Errorf("got: %q, want: %q", sb.String(), "PORT=8080\n")
+	}
+}